@@ -0,0 +1,32 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+import (
+	"log"
+	"os"
+)
+
+// logger is the minimal subset of a structured logger used throughout this module.
+type logger interface {
+	Debugf(format string, args ...interface{})
+	Warningf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+}
+
+type stdLogger struct {
+	*log.Logger
+}
+
+func (l *stdLogger) Debugf(format string, args ...interface{})   { l.Printf("[DEBUG] "+format, args...) }
+func (l *stdLogger) Warningf(format string, args ...interface{}) { l.Printf("[WARN] "+format, args...) }
+func (l *stdLogger) Infof(format string, args ...interface{})    { l.Printf("[INFO] "+format, args...) }
+
+// Logger is the package-wide logger used by pre-params generation and other
+// long-running routines to report progress. It can be swapped out by callers
+// that embed this module into a larger application with its own logging stack.
+var Logger logger = &stdLogger{log.New(os.Stderr, "tss-lib ", log.LstdFlags)}
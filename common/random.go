@@ -0,0 +1,195 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"time"
+)
+
+const mustGetRandomIntMillerRabinRounds = 20
+
+var one = big.NewInt(1)
+
+// GermainPrime holds a Sophie Germain prime p alongside its associated safe prime 2p+1.
+type GermainPrime struct {
+	p      *big.Int
+	safePr *big.Int
+}
+
+func (gp *GermainPrime) Prime() *big.Int     { return gp.p }
+func (gp *GermainPrime) SafePrime() *big.Int { return gp.safePr }
+
+// NewGermainPrime builds a GermainPrime from an already-known (p, safePrime) pair, e.g. when
+// reconstructing one from a checkpoint that only retained the safe prime. p may be nil if
+// the Germain prime itself was not retained.
+func NewGermainPrime(p, safePrime *big.Int) *GermainPrime {
+	return &GermainPrime{p: p, safePr: safePrime}
+}
+
+type germainPrimeJSON struct {
+	P         *big.Int `json:"p"`
+	SafePrime *big.Int `json:"safePrime"`
+}
+
+// MarshalJSON allows a GermainPrime to be embedded in a checkpoint blob.
+func (gp *GermainPrime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(germainPrimeJSON{P: gp.p, SafePrime: gp.safePr})
+}
+
+// UnmarshalJSON allows a GermainPrime to be restored from a checkpoint blob.
+func (gp *GermainPrime) UnmarshalJSON(data []byte) error {
+	var aux germainPrimeJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	gp.p, gp.safePr = aux.P, aux.SafePrime
+	return nil
+}
+
+// PrimeEventKind identifies the stage a safe-prime search worker has reached, reported
+// through a PrimeProgress callback so callers can render progress or export metrics.
+type PrimeEventKind int
+
+const (
+	// CandidateFound fires each time a worker draws a new probable-prime candidate p.
+	CandidateFound PrimeEventKind = iota
+	// PrimalityConfirmed fires once the associated safe prime 2p+1 passes Miller-Rabin.
+	PrimalityConfirmed
+	// SafePrimeFound fires once a (p, 2p+1) pair has been accepted into the result set.
+	SafePrimeFound
+)
+
+// PrimeProgress is invoked from worker goroutines as the search progresses. slot is the
+// index (0-based) of the result slot this event pertains to; attempt is the number of
+// candidates this worker has tried so far. Implementations must be safe for concurrent use.
+type PrimeProgress func(slot, attempt int, kind PrimeEventKind, elapsed time.Duration)
+
+// GetRandomGermainPrimesConcurrent searches for `primeCount` Sophie Germain/safe prime
+// pairs of `bitLen` bits using up to `concurrency` worker goroutines. It blocks until
+// all primes are found. For a cancellable variant see GetRandomGermainPrimesConcurrentWithContext.
+func GetRandomGermainPrimesConcurrent(bitLen, primeCount, concurrency int) []*GermainPrime {
+	// context.Background() is never cancelled, so an error here can only come from the
+	// system random source; it is dropped and nil is returned.
+	primes, _ := GetRandomGermainPrimesConcurrentWithContext(context.Background(), bitLen, primeCount, concurrency)
+	return primes
+}
+
+// GetRandomGermainPrimesConcurrentWithContext behaves like GetRandomGermainPrimesConcurrent
+// but aborts the search as soon as ctx is done. Each worker checks ctx.Done() between
+// candidate attempts so cancellation is prompt and no goroutines or channels are leaked.
+func GetRandomGermainPrimesConcurrentWithContext(ctx context.Context, bitLen, primeCount, concurrency int) ([]*GermainPrime, error) {
+	return GetRandomGermainPrimesConcurrentWithProgress(ctx, bitLen, primeCount, concurrency, nil)
+}
+
+// GetRandomGermainPrimesConcurrentWithProgress behaves like GetRandomGermainPrimesConcurrentWithContext
+// and additionally invokes onProgress as each worker advances through candidate generation,
+// primality confirmation and final acceptance. onProgress may be nil.
+func GetRandomGermainPrimesConcurrentWithProgress(ctx context.Context, bitLen, primeCount, concurrency int, onProgress PrimeProgress) ([]*GermainPrime, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	start := time.Now()
+	derivedCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		results = make([]*GermainPrime, 0, primeCount)
+	)
+	errCh := make(chan error, 1)
+
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			attempt := 0
+			for {
+				select {
+				case <-derivedCtx.Done():
+					return
+				default:
+				}
+
+				mu.Lock()
+				have := len(results)
+				mu.Unlock()
+				if have >= primeCount {
+					return
+				}
+
+				attempt++
+				p, err := rand.Prime(rand.Reader, bitLen-1)
+				if err != nil {
+					select {
+					case errCh <- err:
+						cancel()
+					default:
+					}
+					return
+				}
+				if onProgress != nil {
+					onProgress(have, attempt, CandidateFound, time.Since(start))
+				}
+
+				select {
+				case <-derivedCtx.Done():
+					return
+				default:
+				}
+
+				safePr := new(big.Int).Lsh(p, 1)
+				safePr.Add(safePr, one)
+				if !safePr.ProbablyPrime(mustGetRandomIntMillerRabinRounds) {
+					continue
+				}
+				if onProgress != nil {
+					onProgress(have, attempt, PrimalityConfirmed, time.Since(start))
+				}
+
+				mu.Lock()
+				slot := len(results)
+				appended := slot < primeCount
+				if appended {
+					results = append(results, &GermainPrime{p: p, safePr: safePr})
+				}
+				full := len(results) >= primeCount
+				mu.Unlock()
+				// Only report a slot that was actually used: once primeCount primes are
+				// already found, a still-running worker's result is discarded, and slot
+				// would otherwise be out of the valid [0, primeCount) range.
+				if appended && onProgress != nil {
+					onProgress(slot, attempt, SafePrimeFound, time.Since(start))
+				}
+				if full {
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return nil, err
+	default:
+	}
+	// derivedCtx is also cancelled internally once enough primes are found, so checking it
+	// here would report success as context.Canceled; only the caller's ctx indicates a real
+	// cancellation.
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return results, nil
+}
@@ -0,0 +1,55 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestGetRandomGermainPrimesConcurrentWithContext_Succeeds(t *testing.T) {
+	primes, err := GetRandomGermainPrimesConcurrentWithContext(context.Background(), 24, 2, 2)
+	if err != nil {
+		t.Fatalf("expected success on an uncancelled context, got: %v", err)
+	}
+	if len(primes) != 2 {
+		t.Fatalf("expected 2 primes, got %d", len(primes))
+	}
+	for _, gp := range primes {
+		if gp.SafePrime() == nil {
+			t.Fatal("expected a non-nil safe prime")
+		}
+	}
+}
+
+func TestGetRandomGermainPrimesConcurrentWithContext_CancelStopsWorkers(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	// Ask for far more primes than can plausibly be found before the timeout fires, so the
+	// call is guaranteed to observe cancellation rather than racing it to completion.
+	_, err := GetRandomGermainPrimesConcurrentWithContext(ctx, 512, 64, 8)
+	if err == nil {
+		t.Fatal("expected an error from a cancelled context")
+	}
+	if ctx.Err() == nil || err != ctx.Err() {
+		t.Fatalf("expected the caller's own ctx.Err(), got: %v", err)
+	}
+
+	// Give any lingering worker goroutines a moment to observe cancellation and exit.
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("worker goroutines leaked: had %d before, %d after", before, after)
+	}
+}
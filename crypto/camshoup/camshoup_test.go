@@ -0,0 +1,77 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package camshoup
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+)
+
+func testPreParams(t *testing.T) *keygen.LocalPreParams {
+	t.Helper()
+	sgps := common.GetRandomGermainPrimesConcurrent(24, 2, 2)
+	if len(sgps) != 2 {
+		t.Fatalf("expected 2 safe primes, got %d", len(sgps))
+	}
+	nTildei := new(big.Int).Mul(sgps[0].SafePrime(), sgps[1].SafePrime())
+	return &keygen.LocalPreParams{
+		NTildei:          nTildei,
+		NTildeKind:       keygen.SafePrimeModulus,
+		NTildeSafePrimes: [2]*big.Int{sgps[0].SafePrime(), sgps[1].SafePrime()},
+	}
+}
+
+func TestEncryptDecrypt_RoundTrip(t *testing.T) {
+	pp := testPreParams(t)
+	group, err := NewGroupFromPreParams(pp)
+	if err != nil {
+		t.Fatalf("NewGroupFromPreParams: %v", err)
+	}
+	sk, err := GenerateKeyPair(group)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+
+	m := big.NewInt(42)
+	label := []byte("session-1")
+	ct, err := sk.PublicKey.Encrypt(m, label)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	got, err := sk.Decrypt(ct, label)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if got.Cmp(m) != 0 {
+		t.Fatalf("expected decrypted message %v, got %v", m, got)
+	}
+}
+
+func TestDecrypt_WrongLabelFailsVerification(t *testing.T) {
+	pp := testPreParams(t)
+	group, err := NewGroupFromPreParams(pp)
+	if err != nil {
+		t.Fatalf("NewGroupFromPreParams: %v", err)
+	}
+	sk, err := GenerateKeyPair(group)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+
+	ct, err := sk.PublicKey.Encrypt(big.NewInt(7), []byte("label-a"))
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	if _, err := sk.Decrypt(ct, []byte("label-b")); err == nil {
+		t.Fatal("expected Decrypt to fail verification under a different label")
+	}
+}
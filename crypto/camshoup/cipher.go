@@ -0,0 +1,98 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package camshoup
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// Ciphertext is a Camenisch-Shoup label-bound ciphertext (u, e, v).
+type Ciphertext struct {
+	U, E, V *big.Int
+}
+
+// Encrypt encrypts m under pk, binding the ciphertext to label so it cannot be replayed
+// under a different label (e.g. a different recipient or session). e blinds m with y3^r
+// (an ElGamal-style term under pk's own key) rather than h^r directly, so that only the
+// holder of x2 -- not an arbitrary factorer of n -- can ever peel r back off of e.
+func (pk *PublicKey) Encrypt(m *big.Int, label []byte) (*Ciphertext, error) {
+	r, err := rand.Int(rand.Reader, pk.N2)
+	if err != nil {
+		return nil, err
+	}
+
+	u := new(big.Int).Exp(pk.G, r, pk.N2)
+
+	onePlusN := new(big.Int).Add(pk.N, one)
+	onePlusNToM := new(big.Int).Exp(onePlusN, m, pk.N2)
+	e := new(big.Int).Exp(pk.Y3, r, pk.N2)
+	e.Mul(e, onePlusNToM)
+	e.Mod(e, pk.N2)
+
+	c := challenge(pk.N2, u, e, label)
+	base := new(big.Int).Exp(pk.Y2, c, pk.N2)
+	base.Mul(base, pk.Y1)
+	base.Mod(base, pk.N2)
+	v := new(big.Int).Exp(base, r, pk.N2)
+	v = absRepresentative(v, pk.N2)
+
+	return &Ciphertext{U: u, E: e, V: v}, nil
+}
+
+// Decrypt recovers the plaintext m from ct. Since u=g^r, y3^r = u^x2 -- computable directly
+// from the secret key without ever learning r itself -- so m is recovered by dividing that
+// term back out of e and applying the standard Paillier L-function. It also checks the
+// ciphertext is well-formed for label: since y1=g^x1 and y2=g^x3, (y1*y2^c)^r = u^(x1+c*x3),
+// so v^2 =? (u^(x1+c*x3))^2 mod n^2 holds for any honestly-generated ciphertext, where
+// c = challenge(u, e, label).
+func (sk *PrivateKey) Decrypt(ct *Ciphertext, label []byte) (*big.Int, error) {
+	c := challenge(sk.N2, ct.U, ct.E, label)
+
+	exp := new(big.Int).Mul(c, sk.X3)
+	exp.Add(exp, sk.X1)
+	rhs := new(big.Int).Exp(ct.U, exp, sk.N2)
+	rhs.Exp(rhs, two, sk.N2)
+	lhs := new(big.Int).Exp(ct.V, two, sk.N2)
+	if absRepresentative(lhs, sk.N2).Cmp(absRepresentative(rhs, sk.N2)) != 0 {
+		return nil, errors.New("camshoup: Decrypt: ciphertext failed verification for this label")
+	}
+
+	y3r := new(big.Int).Exp(ct.U, sk.X2, sk.N2)
+	y3rInv := new(big.Int).ModInverse(y3r, sk.N2)
+	if y3rInv == nil {
+		return nil, errors.New("camshoup: Decrypt: y3 is degenerate for this ciphertext")
+	}
+	enc := new(big.Int).Mul(ct.E, y3rInv)
+	enc.Mod(enc, sk.N2)
+
+	return lFunction(enc, sk.N), nil
+}
+
+var two = big.NewInt(2)
+
+// challenge computes the Fiat-Shamir challenge binding a ciphertext to its label.
+func challenge(n2, u, e *big.Int, label []byte) *big.Int {
+	h := sha256.New()
+	h.Write([]byte("CamenischShoup"))
+	h.Write(u.Bytes())
+	h.Write(e.Bytes())
+	h.Write(label)
+	return new(big.Int).Mod(new(big.Int).SetBytes(h.Sum(nil)), n2)
+}
+
+// absRepresentative picks the canonical representative of {v, n2-v} in Z*_n2/{±1}, i.e. the
+// smaller of the two, so verification doesn't depend on which sign a prover happened to use.
+func absRepresentative(v, n2 *big.Int) *big.Int {
+	half := new(big.Int).Rsh(n2, 1)
+	if v.Cmp(half) > 0 {
+		return new(big.Int).Sub(n2, v)
+	}
+	return new(big.Int).Set(v)
+}
@@ -0,0 +1,121 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package camshoup
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+)
+
+// PedersenParams is the (NTildei, H1i, H2i) commitment parameters a party already generated
+// via keygen.GeneratePreParams, reused here as the Pedersen commitment base for proofs about
+// Camenisch-Shoup ciphertexts.
+type PedersenParams struct {
+	N, H1, H2 *big.Int
+}
+
+// NewPedersenParamsFromPreParams reuses an existing party's NTildei/H1i/H2i as Pedersen
+// commitment parameters, avoiding the need to generate a second, unrelated commitment modulus.
+func NewPedersenParamsFromPreParams(pp *keygen.LocalPreParams) *PedersenParams {
+	return &PedersenParams{N: pp.NTildei, H1: pp.H1i, H2: pp.H2i}
+}
+
+// Commit produces a Pedersen commitment C = H1^m * H2^r mod N.
+func (pp *PedersenParams) Commit(m, r *big.Int) *big.Int {
+	c := new(big.Int).Exp(pp.H1, m, pp.N)
+	hr := new(big.Int).Exp(pp.H2, r, pp.N)
+	c.Mul(c, hr)
+	return c.Mod(c, pp.N)
+}
+
+// EqualityProof is a non-interactive zero-knowledge proof that a Pedersen commitment and a
+// Camenisch-Shoup ciphertext hide the same plaintext m, i.e. for the statement "there exist
+// m, r, rEnc such that C = H1^m H2^r (mod N) and ct decrypts to m under pk for this label".
+type EqualityProof struct {
+	A, B          *big.Int
+	Zm, Zr, ZrEnc *big.Int
+}
+
+// ProveEquality proves that commitment = pedersen.Commit(m, r) and ct encrypts the same m
+// under pk for label, without revealing m, r or the encryption randomness rEnc used to form
+// ct (i.e. ct.E = pk.Y3^rEnc * (1+N)^m mod pk.N2, as produced internally by pk.Encrypt).
+func ProveEquality(pk *PublicKey, pedersen *PedersenParams, m, r, rEnc *big.Int, commitment *big.Int, ct *Ciphertext, label []byte) (*EqualityProof, error) {
+	alpha, err := rand.Int(rand.Reader, pedersen.N)
+	if err != nil {
+		return nil, err
+	}
+	gamma, err := rand.Int(rand.Reader, pedersen.N)
+	if err != nil {
+		return nil, err
+	}
+	beta, err := rand.Int(rand.Reader, pk.N2)
+	if err != nil {
+		return nil, err
+	}
+
+	A := pedersen.Commit(alpha, gamma)
+
+	onePlusN := new(big.Int).Add(pk.N, one)
+	B := new(big.Int).Exp(onePlusN, alpha, pk.N2)
+	y3Beta := new(big.Int).Exp(pk.Y3, beta, pk.N2)
+	B.Mul(B, y3Beta)
+	B.Mod(B, pk.N2)
+
+	c := equalityChallenge(commitment, ct, A, B, label)
+
+	zm := new(big.Int).Mul(c, m)
+	zm.Add(zm, alpha)
+
+	zr := new(big.Int).Mul(c, r)
+	zr.Add(zr, gamma)
+
+	zrEnc := new(big.Int).Mul(c, rEnc)
+	zrEnc.Add(zrEnc, beta)
+
+	return &EqualityProof{A: A, B: B, Zm: zm, Zr: zr, ZrEnc: zrEnc}, nil
+}
+
+// VerifyEquality checks a proof produced by ProveEquality against the given commitment and
+// ciphertext.
+func VerifyEquality(pk *PublicKey, pedersen *PedersenParams, commitment *big.Int, ct *Ciphertext, label []byte, proof *EqualityProof) bool {
+	c := equalityChallenge(commitment, ct, proof.A, proof.B, label)
+
+	lhs1 := pedersen.Commit(proof.Zm, proof.Zr)
+	rhs1 := new(big.Int).Exp(commitment, c, pedersen.N)
+	rhs1.Mul(rhs1, proof.A)
+	rhs1.Mod(rhs1, pedersen.N)
+	if lhs1.Cmp(rhs1) != 0 {
+		return false
+	}
+
+	onePlusN := new(big.Int).Add(pk.N, one)
+	lhs2 := new(big.Int).Exp(onePlusN, proof.Zm, pk.N2)
+	y3ZrEnc := new(big.Int).Exp(pk.Y3, proof.ZrEnc, pk.N2)
+	lhs2.Mul(lhs2, y3ZrEnc)
+	lhs2.Mod(lhs2, pk.N2)
+
+	rhs2 := new(big.Int).Exp(ct.E, c, pk.N2)
+	rhs2.Mul(rhs2, proof.B)
+	rhs2.Mod(rhs2, pk.N2)
+
+	return lhs2.Cmp(rhs2) == 0
+}
+
+func equalityChallenge(commitment *big.Int, ct *Ciphertext, A, B *big.Int, label []byte) *big.Int {
+	h := sha256.New()
+	h.Write([]byte("CamenischShoupEquality"))
+	h.Write(commitment.Bytes())
+	h.Write(ct.U.Bytes())
+	h.Write(ct.E.Bytes())
+	h.Write(A.Bytes())
+	h.Write(B.Bytes())
+	h.Write(label)
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
@@ -0,0 +1,68 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package camshoup implements the Camenisch-Shoup verifiable encryption scheme: encrypting
+// a discrete logarithm under a recipient's key together with a zero-knowledge proof that the
+// plaintext equals a value already committed to with a Pedersen commitment. It reuses the
+// safe primes already produced by keygen.GeneratePreParams instead of asking the caller to
+// generate a fresh pair, since that search is the expensive part of setup.
+package camshoup
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+)
+
+var one = big.NewInt(1)
+
+// CSGroup holds the Camenisch-Shoup public group parameters (n, g, h). n's factorization is
+// not retained here: decryption does not use a factorization-based trapdoor, only the
+// secret key x1, x2, x3 (see PrivateKey.Decrypt).
+type CSGroup struct {
+	N, N2 *big.Int
+	G, H  *big.Int
+}
+
+// NewGroupFromPreParams derives the Camenisch-Shoup group (n, g, h) from pp: n = p*q is
+// pp.NTildei, reusing the safe primes p=2p'+1, q=2q'+1 that keygen.GeneratePreParams already
+// found for NTildei. g is a random 2n-th residue mod n^2, and h = 1+n mod n^2 is the
+// standard order-n generator used to encode the plaintext exponent in Encrypt. It fails if pp
+// was produced by keygen.GeneratePreParamsFast, since that mode derives NTildei from
+// ordinary primes and retains none of them.
+func NewGroupFromPreParams(pp *keygen.LocalPreParams) (*CSGroup, error) {
+	if err := pp.RequireSafePrimes(); err != nil {
+		return nil, err
+	}
+	P, Q := pp.NTildeSafePrimes[0], pp.NTildeSafePrimes[1]
+	if P == nil || Q == nil {
+		return nil, errors.New("camshoup: NewGroupFromPreParams: pre-params do not retain their safe primes")
+	}
+
+	n := pp.NTildei
+	n2 := new(big.Int).Mul(n, n)
+
+	w, err := rand.Int(rand.Reader, n2)
+	if err != nil {
+		return nil, err
+	}
+	twoN := new(big.Int).Lsh(n, 1)
+	g := new(big.Int).Exp(w, twoN, n2)
+
+	h := new(big.Int).Add(n, one)
+	h.Mod(h, n2)
+
+	return &CSGroup{N: n, N2: n2, G: g, H: h}, nil
+}
+
+// lFunction is the standard Paillier-style decoding function L(x) = (x-1)/n, valid for any x
+// congruent to 1 mod n.
+func lFunction(x, n *big.Int) *big.Int {
+	t := new(big.Int).Sub(x, one)
+	return t.Div(t, n)
+}
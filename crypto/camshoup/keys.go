@@ -0,0 +1,55 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package camshoup
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// PublicKey is a Camenisch-Shoup public key over a CSGroup: y1 = g^x1, y2 = g^x3 are used for
+// the well-formedness tag v (v = (y1*y2^c)^r, checked as v^2 =? (u^(x1+c*x3))^2 during
+// Decrypt); y3 = g^x2 is the ElGamal-style encryption key that blinds the plaintext term in e
+// (e = y3^r * h^m), letting the holder of x2 peel r's contribution off of e and recover m via
+// the standard Paillier L-function, without needing n's factorization at all.
+type PublicKey struct {
+	*CSGroup
+	Y1, Y2, Y3 *big.Int
+}
+
+// PrivateKey is a Camenisch-Shoup private key.
+type PrivateKey struct {
+	PublicKey
+	X1, X2, X3 *big.Int
+}
+
+// GenerateKeyPair samples a fresh Camenisch-Shoup key pair over group.
+func GenerateKeyPair(group *CSGroup) (*PrivateKey, error) {
+	x1, err := rand.Int(rand.Reader, group.N2)
+	if err != nil {
+		return nil, err
+	}
+	x2, err := rand.Int(rand.Reader, group.N2)
+	if err != nil {
+		return nil, err
+	}
+	x3, err := rand.Int(rand.Reader, group.N2)
+	if err != nil {
+		return nil, err
+	}
+
+	y1 := new(big.Int).Exp(group.G, x1, group.N2)
+	y2 := new(big.Int).Exp(group.G, x3, group.N2)
+	y3 := new(big.Int).Exp(group.G, x2, group.N2)
+
+	return &PrivateKey{
+		PublicKey: PublicKey{CSGroup: group, Y1: y1, Y2: y2, Y3: y3},
+		X1:        x1,
+		X2:        x2,
+		X3:        x3,
+	}, nil
+}
@@ -0,0 +1,36 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+)
+
+// GenerateNTildei derives the NTildei modulus and the h1i, h2i generators used by the
+// range proofs in the signing protocol from a pair of safe primes.
+func GenerateNTildei(safePrimes [2]*big.Int) (NTildei, h1i, h2i *big.Int, err error) {
+	if safePrimes[0] == nil || safePrimes[1] == nil {
+		return nil, nil, nil, errors.New("GenerateNTildei: safePrimes must not be nil")
+	}
+	NTildei = new(big.Int).Mul(safePrimes[0], safePrimes[1])
+
+	f, err := rand.Int(rand.Reader, NTildei)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	h1i = new(big.Int).Exp(f, big.NewInt(2), NTildei)
+
+	x, err := rand.Int(rand.Reader, NTildei)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	h2i = new(big.Int).Exp(h1i, x, NTildei)
+
+	return NTildei, h1i, h2i, nil
+}
@@ -0,0 +1,76 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package paillier
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+)
+
+var one = big.NewInt(1)
+
+// PublicKey is a Paillier public key: the modulus N.
+type PublicKey struct {
+	N *big.Int
+}
+
+// PrivateKey is a Paillier private key; LambdaN and PhiN are retained to speed up decryption.
+type PrivateKey struct {
+	PublicKey
+	LambdaN, PhiN *big.Int
+}
+
+// GenerateKeyPair generates a Paillier key pair of the given modulus bit length, blocking
+// until two suitable primes are found. Use GenerateKeyPairWithContext to make this cancellable.
+func GenerateKeyPair(modulusBitLen int, optionalConcurrency ...int) (privateKey *PrivateKey, publicKey *PublicKey) {
+	// context.Background() is never cancelled, so an error here can only come from the
+	// system random source; it is dropped.
+	privateKey, publicKey, _ = GenerateKeyPairWithContext(context.Background(), modulusBitLen)
+	return
+}
+
+// GenerateKeyPairWithContext generates a Paillier key pair, checking ctx before each draw of
+// a candidate pair of primes so that a caller can abort a long-running search.
+func GenerateKeyPairWithContext(ctx context.Context, modulusBitLen int) (privateKey *PrivateKey, publicKey *PublicKey, err error) {
+	primeBitLen := modulusBitLen / 2
+	var p, q *big.Int
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		default:
+		}
+		p, err = rand.Prime(rand.Reader, primeBitLen)
+		if err != nil {
+			return nil, nil, err
+		}
+		q, err = rand.Prime(rand.Reader, primeBitLen)
+		if err != nil {
+			return nil, nil, err
+		}
+		if p.Cmp(q) != 0 {
+			break
+		}
+	}
+
+	n := new(big.Int).Mul(p, q)
+	pMinus1 := new(big.Int).Sub(p, one)
+	qMinus1 := new(big.Int).Sub(q, one)
+	phiN := new(big.Int).Mul(pMinus1, qMinus1)
+
+	gcd := new(big.Int).GCD(nil, nil, pMinus1, qMinus1)
+	lambdaN := new(big.Int).Div(phiN, gcd)
+
+	publicKey = &PublicKey{N: n}
+	privateKey = &PrivateKey{
+		PublicKey: *publicKey,
+		LambdaN:   lambdaN,
+		PhiN:      phiN,
+	}
+	return privateKey, publicKey, nil
+}
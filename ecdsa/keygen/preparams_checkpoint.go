@@ -0,0 +1,217 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+)
+
+// MarshalCheckpoint encodes a fully-populated LocalPreParams as a checkpoint blob that can
+// be handed back to ResumePreParams without redoing any work.
+func (preParams *LocalPreParams) MarshalCheckpoint() ([]byte, error) {
+	if !preParams.Validate() {
+		return nil, errors.New("MarshalCheckpoint: preParams is not fully populated")
+	}
+	cp := &preParamsCheckpoint{
+		PaillierSK: preParams.PaillierSK,
+		NTildei:    preParams.NTildei,
+		H1i:        preParams.H1i,
+		H2i:        preParams.H2i,
+		NTildeKind: preParams.NTildeKind,
+	}
+	if preParams.NTildeKind == SafePrimeModulus {
+		cp.SafePrimes = [2]*common.GermainPrime{
+			common.NewGermainPrime(nil, preParams.NTildeSafePrimes[0]),
+			common.NewGermainPrime(nil, preParams.NTildeSafePrimes[1]),
+		}
+	}
+	return json.Marshal(cp)
+}
+
+// preParamsCheckpoint is the on-the-wire representation used by MarshalCheckpoint,
+// GeneratePreParamsWithCheckpoint and ResumePreParams.
+type preParamsCheckpoint struct {
+	PaillierSK *paillier.PrivateKey    `json:"paillierSK,omitempty"`
+	SafePrimes [2]*common.GermainPrime `json:"safePrimes"`
+	NTildei    *big.Int                `json:"nTildei,omitempty"`
+	H1i        *big.Int                `json:"h1i,omitempty"`
+	H2i        *big.Int                `json:"h2i,omitempty"`
+	NTildeKind PaillierKeyKind         `json:"nTildeKind"`
+}
+
+// ResumePreParams resumes pre-params generation from a checkpoint previously produced by
+// GeneratePreParamsWithCheckpoint or LocalPreParams.MarshalCheckpoint. A nil/empty
+// checkpoint starts from scratch. It does not persist further checkpoints as it runs; use
+// GeneratePreParamsWithCheckpoint directly if you need that.
+func ResumePreParams(ctx context.Context, checkpoint []byte, concurrency int) (*LocalPreParams, error) {
+	return GeneratePreParamsWithCheckpoint(ctx, concurrency, checkpoint, nil)
+}
+
+// GeneratePreParamsWithCheckpoint behaves like GeneratePreParamsWithOptions, but accepts a
+// checkpoint (as produced by a previous call to this function, to ResumePreParams, or to
+// LocalPreParams.MarshalCheckpoint) to resume from, and invokes saveCheckpoint after each of
+// the three independently-restartable phases completes (Paillier keygen, safe prime search,
+// NTildei derivation). All missing safe primes are searched for in one phase and
+// checkpointed together once that search ends, so a process killed mid-search loses any
+// safe prime already found in it. saveCheckpoint may be nil, in which case no checkpoints
+// are persisted.
+func GeneratePreParamsWithCheckpoint(
+	ctx context.Context,
+	concurrency int,
+	checkpoint []byte,
+	saveCheckpoint func(state []byte) error,
+	opts ...PreParamsOption,
+) (*LocalPreParams, error) {
+	if concurrency < 1 {
+		concurrency = runtime.NumCPU()
+	}
+	cfg := &preParamsConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	cp := &preParamsCheckpoint{}
+	if len(checkpoint) > 0 {
+		if err := json.Unmarshal(checkpoint, cp); err != nil {
+			return nil, err
+		}
+	}
+
+	var saveMu sync.Mutex
+	save := func() error {
+		if saveCheckpoint == nil {
+			return nil
+		}
+		saveMu.Lock()
+		b, err := json.Marshal(cp)
+		saveMu.Unlock()
+		if err != nil {
+			return err
+		}
+		return saveCheckpoint(b)
+	}
+
+	start := time.Now()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, 2)
+
+	if cp.PaillierSK == nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			paiSK, err := generatePaillierPhase(ctx)
+			if err != nil {
+				errCh <- err
+				cancel()
+				return
+			}
+			cfg.emit(EventPaillierDone, -1, 0, time.Since(start))
+			saveMu.Lock()
+			cp.PaillierSK = paiSK
+			saveMu.Unlock()
+			if err := save(); err != nil {
+				errCh <- err
+				cancel()
+			}
+		}()
+	}
+
+	missing := 0
+	if cp.NTildeKind == SafePrimeModulus {
+		for _, sgp := range cp.SafePrimes {
+			if sgp == nil {
+				missing++
+			}
+		}
+	}
+	if missing > 0 {
+		onProgress := cfg.asPrimeProgress(start)
+		if onProgress != nil && cp.SafePrimes[0] != nil {
+			// Only the second safe prime is being searched for, so report it as slot 1.
+			report := onProgress
+			onProgress = func(slot, attempt int, kind common.PrimeEventKind, elapsed time.Duration) {
+				report(slot+1, attempt, kind, elapsed)
+			}
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sgps, err := generateSafePrimesPhase(ctx, missing, concurrency, onProgress)
+			if err != nil {
+				errCh <- err
+				cancel()
+				return
+			}
+			saveMu.Lock()
+			next := 0
+			for idx, existing := range cp.SafePrimes {
+				if existing != nil {
+					continue
+				}
+				cp.SafePrimes[idx] = sgps[next]
+				next++
+			}
+			saveMu.Unlock()
+			if err := save(); err != nil {
+				errCh <- err
+				cancel()
+			}
+		}()
+	}
+
+	wg.Wait()
+	select {
+	case err := <-errCh:
+		return nil, err
+	default:
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if cp.NTildei == nil {
+		if cp.NTildeKind != SafePrimeModulus {
+			return nil, errors.New("GeneratePreParamsWithCheckpoint: checkpoint has NTildeKind OrdinaryPrimeModulus but no NTildei; resuming GeneratePreParamsFast checkpoints is not supported")
+		}
+		NTildei, h1i, h2i, err := deriveNTildePhase(cp.SafePrimes[0], cp.SafePrimes[1])
+		if err != nil {
+			return nil, err
+		}
+		cp.NTildei, cp.H1i, cp.H2i = NTildei, h1i, h2i
+		cfg.emit(EventNTildeDone, -1, 0, time.Since(start))
+		if err := save(); err != nil {
+			return nil, err
+		}
+	}
+
+	preParams := &LocalPreParams{
+		PaillierSK: cp.PaillierSK,
+		NTildei:    cp.NTildei,
+		H1i:        cp.H1i,
+		H2i:        cp.H2i,
+		NTildeKind: cp.NTildeKind,
+	}
+	if cp.NTildeKind == SafePrimeModulus {
+		preParams.NTildeSafePrimes = [2]*big.Int{
+			cp.SafePrimes[0].SafePrime(),
+			cp.SafePrimes[1].SafePrime(),
+		}
+	}
+	return preParams, nil
+}
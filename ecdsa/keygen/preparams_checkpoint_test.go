@@ -0,0 +1,79 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+)
+
+func testFullPreParams(t *testing.T) *LocalPreParams {
+	t.Helper()
+	paiSK, _ := paillier.GenerateKeyPair(64)
+	return &LocalPreParams{
+		PaillierSK:       paiSK,
+		NTildei:          big.NewInt(143), // 11*13: a stand-in modulus, just fast enough for a round-trip test
+		H1i:              big.NewInt(7),
+		H2i:              big.NewInt(9),
+		NTildeKind:       SafePrimeModulus,
+		NTildeSafePrimes: [2]*big.Int{big.NewInt(11), big.NewInt(13)},
+	}
+}
+
+func TestMarshalCheckpoint_RoundTrip(t *testing.T) {
+	pp := testFullPreParams(t)
+	blob, err := pp.MarshalCheckpoint()
+	if err != nil {
+		t.Fatalf("MarshalCheckpoint: %v", err)
+	}
+
+	resumed, err := GeneratePreParamsWithCheckpoint(context.Background(), 1, blob, nil)
+	if err != nil {
+		t.Fatalf("GeneratePreParamsWithCheckpoint: %v", err)
+	}
+
+	if resumed.H1i == nil || resumed.H1i.Cmp(pp.H1i) != 0 {
+		t.Fatalf("H1i did not round-trip: got %v, want %v", resumed.H1i, pp.H1i)
+	}
+	if resumed.H2i == nil || resumed.H2i.Cmp(pp.H2i) != 0 {
+		t.Fatalf("H2i did not round-trip: got %v, want %v", resumed.H2i, pp.H2i)
+	}
+	if resumed.NTildei == nil || resumed.NTildei.Cmp(pp.NTildei) != 0 {
+		t.Fatalf("NTildei did not round-trip: got %v, want %v", resumed.NTildei, pp.NTildei)
+	}
+	if resumed.NTildeKind != pp.NTildeKind {
+		t.Fatalf("NTildeKind did not round-trip: got %v, want %v", resumed.NTildeKind, pp.NTildeKind)
+	}
+	if !resumed.Validate() {
+		t.Fatal("expected resumed pre-params to be fully populated")
+	}
+}
+
+func TestMarshalCheckpoint_OrdinaryPrimeModulusOmitsSafePrimes(t *testing.T) {
+	pp := testFullPreParams(t)
+	pp.NTildeKind = OrdinaryPrimeModulus
+	pp.NTildeSafePrimes = [2]*big.Int{}
+
+	blob, err := pp.MarshalCheckpoint()
+	if err != nil {
+		t.Fatalf("MarshalCheckpoint: %v", err)
+	}
+
+	resumed, err := GeneratePreParamsWithCheckpoint(context.Background(), 1, blob, nil)
+	if err != nil {
+		t.Fatalf("GeneratePreParamsWithCheckpoint: %v", err)
+	}
+	if resumed.NTildeKind != OrdinaryPrimeModulus {
+		t.Fatalf("expected NTildeKind to round-trip as OrdinaryPrimeModulus, got %v", resumed.NTildeKind)
+	}
+	if resumed.NTildeSafePrimes[0] != nil || resumed.NTildeSafePrimes[1] != nil {
+		t.Fatal("expected NTildeSafePrimes to stay nil for OrdinaryPrimeModulus pre-params")
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"runtime"
+	"time"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+)
+
+// GeneratePreParamsFast behaves like GeneratePreParamsWithOptions, but derives NTildei from
+// two ordinary probable primes (via Go's rand.Prime) instead of Sophie-Germain/safe primes,
+// which cuts generation time by orders of magnitude. The resulting LocalPreParams has
+// NTildeKind set to OrdinaryPrimeModulus.
+//
+// This is only safe for protocols that do not rely on NTildei having safe-prime structure
+// (e.g. experimentation, or Lindell-17-style protocols). The GG18 range proofs used
+// elsewhere in this module assume safe primes; running them against OrdinaryPrimeModulus
+// pre-params is insecure, which is why RequireSafePrimes exists for such call sites to
+// refuse with a clear error rather than silently producing an unsound proof.
+func GeneratePreParamsFast(ctx context.Context, concurrency int, opts ...PreParamsOption) (*LocalPreParams, error) {
+	if concurrency < 1 {
+		concurrency = runtime.NumCPU()
+	}
+	cfg := &preParamsConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	start := time.Now()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	paiCh := make(chan *paillier.PrivateKey, 1)
+	primeCh := make(chan [2]*big.Int, 1)
+	errCh := make(chan error, 2)
+
+	go func() {
+		paiSK, err := generatePaillierPhase(ctx)
+		if err != nil {
+			errCh <- err
+			cancel()
+			return
+		}
+		cfg.emit(EventPaillierDone, -1, 0, time.Since(start))
+		paiCh <- paiSK
+	}()
+
+	go func() {
+		primes, err := generateOrdinaryPrimesPhase(ctx)
+		if err != nil {
+			errCh <- err
+			cancel()
+			return
+		}
+		primeCh <- primes
+	}()
+
+	var paiSK *paillier.PrivateKey
+	var primes [2]*big.Int
+	for paiSK == nil || primes[0] == nil {
+		select {
+		case paiSK = <-paiCh:
+		case primes = <-primeCh:
+		case err := <-errCh:
+			return nil, err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	NTildei, h1i, h2i, err := crypto.GenerateNTildei(primes)
+	if err != nil {
+		return nil, err
+	}
+	cfg.emit(EventNTildeDone, -1, 0, time.Since(start))
+
+	return &LocalPreParams{
+		PaillierSK: paiSK,
+		NTildei:    NTildei,
+		H1i:        h1i,
+		H2i:        h2i,
+		NTildeKind: OrdinaryPrimeModulus,
+	}, nil
+}
+
+// generateOrdinaryPrimesPhase draws two ordinary probable primes, checking ctx between each
+// so the caller can abort a slow draw.
+func generateOrdinaryPrimesPhase(ctx context.Context) ([2]*big.Int, error) {
+	var primes [2]*big.Int
+	for i := range primes {
+		select {
+		case <-ctx.Done():
+			return primes, ctx.Err()
+		default:
+		}
+		p, err := rand.Prime(rand.Reader, SafePrimeBitLen)
+		if err != nil {
+			return primes, err
+		}
+		primes[i] = p
+	}
+	return primes, nil
+}
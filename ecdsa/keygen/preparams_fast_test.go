@@ -0,0 +1,31 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGeneratePreParamsFast_ProducesOrdinaryPrimeModulus(t *testing.T) {
+	pp, err := GeneratePreParamsFast(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("GeneratePreParamsFast: %v", err)
+	}
+	if !pp.Validate() {
+		t.Fatal("expected fully populated pre-params")
+	}
+	if pp.NTildeKind != OrdinaryPrimeModulus {
+		t.Fatalf("expected NTildeKind OrdinaryPrimeModulus, got %v", pp.NTildeKind)
+	}
+	if pp.NTildeSafePrimes[0] != nil || pp.NTildeSafePrimes[1] != nil {
+		t.Fatal("expected NTildeSafePrimes to stay nil for GeneratePreParamsFast output")
+	}
+	if err := pp.RequireSafePrimes(); err == nil {
+		t.Fatal("expected RequireSafePrimes to reject OrdinaryPrimeModulus pre-params")
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"time"
+
+	"github.com/binance-chain/tss-lib/common"
+)
+
+// PreParamsEventKind identifies which stage of pre-params generation an event was emitted for.
+type PreParamsEventKind int
+
+const (
+	// EventCandidateFound fires when a new probable-prime candidate for one of the two
+	// safe primes has been drawn.
+	EventCandidateFound PreParamsEventKind = iota
+	// EventPrimalityConfirmed fires once a candidate's associated safe prime passes Miller-Rabin.
+	EventPrimalityConfirmed
+	// EventSafePrimeFound fires once one of the two safe primes has been accepted.
+	EventSafePrimeFound
+	// EventPaillierDone fires once the Paillier key pair has been generated.
+	EventPaillierDone
+	// EventNTildeDone fires once NTildei, h1i and h2i have been derived.
+	EventNTildeDone
+)
+
+// PreParamsEvent is passed to the callback registered with WithProgressCallback as
+// pre-params generation advances.
+type PreParamsEvent struct {
+	Kind PreParamsEventKind
+	// SafePrimeIdx is the index (0 or 1) of the safe prime this event pertains to.
+	// It is only meaningful for EventCandidateFound, EventPrimalityConfirmed and EventSafePrimeFound.
+	SafePrimeIdx int
+	// Attempt is the number of candidates the reporting worker has tried so far in this
+	// search, counted across both safe primes rather than reset per slot.
+	Attempt int
+	// Elapsed is the time since the start of GeneratePreParams.
+	Elapsed time.Duration
+}
+
+// preParamsConfig is built up by PreParamsOption functions passed to GeneratePreParamsWithOptions.
+type preParamsConfig struct {
+	progressCallback func(PreParamsEvent)
+}
+
+// PreParamsOption customizes GeneratePreParamsWithOptions.
+type PreParamsOption func(*preParamsConfig)
+
+// WithProgressCallback registers a callback invoked from worker goroutines as pre-params
+// generation advances through candidate search, primality confirmation, safe prime
+// acceptance, Paillier keygen and NTildei derivation. The callback must be safe for
+// concurrent use and should not block, since it runs on the generation's hot path.
+func WithProgressCallback(cb func(PreParamsEvent)) PreParamsOption {
+	return func(c *preParamsConfig) { c.progressCallback = cb }
+}
+
+func (c *preParamsConfig) emit(kind PreParamsEventKind, safePrimeIdx, attempt int, elapsed time.Duration) {
+	if c.progressCallback == nil {
+		return
+	}
+	c.progressCallback(PreParamsEvent{
+		Kind:         kind,
+		SafePrimeIdx: safePrimeIdx,
+		Attempt:      attempt,
+		Elapsed:      elapsed,
+	})
+}
+
+func (c *preParamsConfig) asPrimeProgress(start time.Time) common.PrimeProgress {
+	if c.progressCallback == nil {
+		return nil
+	}
+	return func(slot, attempt int, kind common.PrimeEventKind, _ time.Duration) {
+		var mapped PreParamsEventKind
+		switch kind {
+		case common.CandidateFound:
+			mapped = EventCandidateFound
+		case common.PrimalityConfirmed:
+			mapped = EventPrimalityConfirmed
+		case common.SafePrimeFound:
+			mapped = EventSafePrimeFound
+		default:
+			return
+		}
+		c.emit(mapped, slot, attempt, time.Since(start))
+	}
+}
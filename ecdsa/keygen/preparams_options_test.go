@@ -0,0 +1,61 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/binance-chain/tss-lib/common"
+)
+
+func TestAsPrimeProgress_MapsEventKindsAndSlot(t *testing.T) {
+	var got []PreParamsEvent
+	cfg := &preParamsConfig{}
+	WithProgressCallback(func(e PreParamsEvent) { got = append(got, e) })(cfg)
+
+	report := cfg.asPrimeProgress(time.Now())
+	report(1, 3, common.CandidateFound, 0)
+	report(1, 3, common.PrimalityConfirmed, 0)
+	report(1, 4, common.SafePrimeFound, 0)
+
+	if len(got) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(got))
+	}
+	wantKinds := []PreParamsEventKind{EventCandidateFound, EventPrimalityConfirmed, EventSafePrimeFound}
+	for i, e := range got {
+		if e.Kind != wantKinds[i] {
+			t.Fatalf("event %d: expected kind %v, got %v", i, wantKinds[i], e.Kind)
+		}
+		if e.SafePrimeIdx != 1 {
+			t.Fatalf("event %d: expected SafePrimeIdx 1, got %d", i, e.SafePrimeIdx)
+		}
+	}
+	if got[2].Attempt != 4 {
+		t.Fatalf("expected last event's Attempt to be 4, got %d", got[2].Attempt)
+	}
+}
+
+func TestAsPrimeProgress_NilCallbackYieldsNilProgress(t *testing.T) {
+	cfg := &preParamsConfig{}
+	if report := cfg.asPrimeProgress(time.Now()); report != nil {
+		t.Fatal("expected a nil PrimeProgress when no callback was registered")
+	}
+}
+
+func TestEmit_PaillierAndNTildeDone(t *testing.T) {
+	var got []PreParamsEvent
+	cfg := &preParamsConfig{}
+	WithProgressCallback(func(e PreParamsEvent) { got = append(got, e) })(cfg)
+
+	cfg.emit(EventPaillierDone, -1, 0, 0)
+	cfg.emit(EventNTildeDone, -1, 0, 0)
+
+	if len(got) != 2 || got[0].Kind != EventPaillierDone || got[1].Kind != EventNTildeDone {
+		t.Fatalf("expected [EventPaillierDone, EventNTildeDone], got %v", got)
+	}
+}
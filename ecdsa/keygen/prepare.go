@@ -7,6 +7,7 @@
 package keygen
 
 import (
+	"context"
 	"errors"
 	"math/big"
 	"runtime"
@@ -21,49 +22,128 @@ import (
 // This can be a time consuming process so it is recommended to do it out-of-band.
 // If not specified, a concurrency value equal to the number of available CPU cores will be used.
 func GeneratePreParams(optionalConcurrency ...int) (*LocalPreParams, error) {
+	return GeneratePreParamsWithContext(context.Background(), optionalConcurrency...)
+}
+
+// GeneratePreParamsWithContext behaves like GeneratePreParams but aborts as soon as ctx is
+// done, returning ctx.Err(). Cancellation is checked between every prime search iteration in
+// both the Paillier keygen goroutine and the safe prime worker pool, so a cancelled call
+// always returns promptly without leaking goroutines or channels.
+func GeneratePreParamsWithContext(ctx context.Context, optionalConcurrency ...int) (*LocalPreParams, error) {
 	var concurrency int
 	if 0 < len(optionalConcurrency) {
 		if 1 < len(optionalConcurrency) {
-			panic(errors.New("GeneratePreParams: expected 0 or 1 item in `optionalConcurrency`"))
+			panic(errors.New("GeneratePreParamsWithContext: expected 0 or 1 item in `optionalConcurrency`"))
 		}
 		concurrency = optionalConcurrency[0]
 	} else {
 		concurrency = runtime.NumCPU()
 	}
+	return GeneratePreParamsWithOptions(ctx, concurrency)
+}
+
+// GeneratePreParamsWithOptions behaves like GeneratePreParamsWithContext but additionally
+// accepts PreParamsOptions such as WithProgressCallback, which is invoked as generation
+// passes through candidate search, primality confirmation, safe prime acceptance, Paillier
+// keygen and NTildei derivation. A concurrency of 0 uses the number of available CPU cores.
+func GeneratePreParamsWithOptions(ctx context.Context, concurrency int, opts ...PreParamsOption) (*LocalPreParams, error) {
+	if concurrency < 1 {
+		concurrency = runtime.NumCPU()
+	}
+
+	cfg := &preParamsConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	start := time.Now()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
 	// prepare for concurrent Paillier and safe prime generation
-	paiCh := make(chan *paillier.PrivateKey)
-	sgpCh := make(chan []*common.GermainPrime)
+	paiCh := make(chan *paillier.PrivateKey, 1)
+	sgpCh := make(chan []*common.GermainPrime, 1)
+	errCh := make(chan error, 2)
 
 	// 4. generate Paillier public key "Ei", private key and proof
 	go func(ch chan<- *paillier.PrivateKey) {
-		start := time.Now()
-		PiPaillierSk, _ := paillier.GenerateKeyPair(PaillierModulusLen) // sk contains pk
-		common.Logger.Debugf("paillier keygen done. took %s\n", time.Since(start))
-		ch <- PiPaillierSk
+		paiSK, err := generatePaillierPhase(ctx)
+		if err != nil {
+			errCh <- err
+			cancel()
+			return
+		}
+		cfg.emit(EventPaillierDone, -1, 0, time.Since(start))
+		ch <- paiSK
 	}(paiCh)
 
 	// 5-7. generate safe primes for ZKPs used later on
 	go func(ch chan<- []*common.GermainPrime) {
-		start := time.Now()
-		sgps := common.GetRandomGermainPrimesConcurrent(SafePrimeBitLen, 2, concurrency)
-		common.Logger.Debugf("safe primes generated. took %s\n", time.Since(start))
+		sgps, err := generateSafePrimesPhase(ctx, 2, concurrency, cfg.asPrimeProgress(start))
+		if err != nil {
+			errCh <- err
+			cancel()
+			return
+		}
 		ch <- sgps
 	}(sgpCh)
 
 	// errors can be thrown in the following code; consume chans to end goroutines here
-	sgps, paiSK := <-sgpCh, <-paiCh
+	var sgps []*common.GermainPrime
+	var paiSK *paillier.PrivateKey
+	for sgps == nil || paiSK == nil {
+		select {
+		case sgps = <-sgpCh:
+		case paiSK = <-paiCh:
+		case err := <-errCh:
+			return nil, err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
 
-	NTildei, h1i, h2i, err := crypto.GenerateNTildei([2]*big.Int{sgps[0].SafePrime(), sgps[1].SafePrime()})
+	NTildei, h1i, h2i, err := deriveNTildePhase(sgps[0], sgps[1])
 	if err != nil {
 		return nil, err
 	}
+	cfg.emit(EventNTildeDone, -1, 0, time.Since(start))
 
 	preParams := &LocalPreParams{
-		PaillierSK: paiSK,
-		NTildei:    NTildei,
-		H1i:        h1i,
-		H2i:        h2i,
+		PaillierSK:       paiSK,
+		NTildei:          NTildei,
+		H1i:              h1i,
+		H2i:              h2i,
+		NTildeSafePrimes: [2]*big.Int{sgps[0].SafePrime(), sgps[1].SafePrime()},
 	}
 	return preParams, nil
+}
+
+// generatePaillierPhase generates the Paillier key pair. It is one of the three independently
+// restartable phases of pre-params generation; see GeneratePreParamsWithCheckpoint.
+func generatePaillierPhase(ctx context.Context) (*paillier.PrivateKey, error) {
+	start := time.Now()
+	paiSK, _, err := paillier.GenerateKeyPairWithContext(ctx, PaillierModulusLen)
+	if err != nil {
+		return nil, err
+	}
+	common.Logger.Debugf("paillier keygen done. took %s\n", time.Since(start))
+	return paiSK, nil
+}
+
+// generateSafePrimesPhase searches for `count` Germain/safe prime pairs. It is one of the
+// three independently restartable phases of pre-params generation; see GeneratePreParamsWithCheckpoint.
+func generateSafePrimesPhase(ctx context.Context, count, concurrency int, onProgress common.PrimeProgress) ([]*common.GermainPrime, error) {
+	start := time.Now()
+	sgps, err := common.GetRandomGermainPrimesConcurrentWithProgress(ctx, SafePrimeBitLen, count, concurrency, onProgress)
+	if err != nil {
+		return nil, err
+	}
+	common.Logger.Debugf("safe primes generated. took %s\n", time.Since(start))
+	return sgps, nil
+}
+
+// deriveNTildePhase derives NTildei, h1i and h2i from the two safe primes. It is the final
+// phase of pre-params generation; see GeneratePreParamsWithCheckpoint.
+func deriveNTildePhase(sgp0, sgp1 *common.GermainPrime) (NTildei, h1i, h2i *big.Int, err error) {
+	return crypto.GenerateNTildei([2]*big.Int{sgp0.SafePrime(), sgp1.SafePrime()})
 }
\ No newline at end of file
@@ -0,0 +1,66 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+)
+
+const (
+	PaillierModulusLen = 2048
+	SafePrimeBitLen    = 1024
+)
+
+// PaillierKeyKind records which kind of primes NTildei was derived from. The default,
+// SafePrimeModulus, is required by the GG18 range proofs; OrdinaryPrimeModulus is only
+// produced by GeneratePreParamsFast and is unsafe for any proof that assumes safe-prime
+// structure. See LocalPreParams.RequireSafePrimes.
+type PaillierKeyKind int
+
+const (
+	SafePrimeModulus PaillierKeyKind = iota
+	OrdinaryPrimeModulus
+)
+
+// LocalPreParams holds the pre-computable, message-independent material required to run
+// the keygen protocol: the local party's Paillier key pair and the NTildei/h1i/h2i values
+// used by the ZK range proofs.
+type LocalPreParams struct {
+	PaillierSK *paillier.PrivateKey
+	NTildei    *big.Int
+	H1i, H2i   *big.Int
+	// NTildeKind is SafePrimeModulus unless these pre-params were produced by
+	// GeneratePreParamsFast, in which case it is OrdinaryPrimeModulus.
+	NTildeKind PaillierKeyKind
+	// NTildeSafePrimes holds the two safe primes P,Q (NTildei = P*Q) when NTildeKind is
+	// SafePrimeModulus. It is nil when NTildeKind is OrdinaryPrimeModulus. Packages that
+	// build verifiable-encryption schemes on top of NTildei's safe-prime structure (e.g.
+	// crypto/camshoup) need this to derive their own key material.
+	NTildeSafePrimes [2]*big.Int
+}
+
+// Validate returns true if the pre-params look fully populated.
+func (preParams *LocalPreParams) Validate() bool {
+	return preParams != nil &&
+		preParams.PaillierSK != nil &&
+		preParams.NTildei != nil &&
+		preParams.H1i != nil &&
+		preParams.H2i != nil
+}
+
+// RequireSafePrimes returns an error if these pre-params were produced by
+// GeneratePreParamsFast from ordinary rather than Sophie-Germain/safe primes. Call this
+// before running any ZK proof that assumes NTildei has safe-prime structure.
+func (preParams *LocalPreParams) RequireSafePrimes() error {
+	if preParams.NTildeKind != SafePrimeModulus {
+		return errors.New("preParams: this operation requires NTildei to be derived from safe primes, but these pre-params used GeneratePreParamsFast (OrdinaryPrimeModulus)")
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// DealerShares is what a single dealer produces during the DKG: a degree-Threshold secret
+// polynomial, Feldman commitments to its coefficients (so recipients can verify the share
+// they are given), the resulting Shamir share for every party in the group, and a Schnorr
+// proof of knowledge of its own secret coefficient. The proof is what gives the DKG
+// rogue-key resistance: without it a dealer could publish a commitment derived from the
+// other dealers' public commitments, biasing the resulting group key toward a key it alone
+// controls.
+type DealerShares struct {
+	Commitments []*ECPoint       // Feldman commitments to the dealer's polynomial coefficients
+	Shares      map[int]*big.Int // party index -> f_dealer(partyIndex)
+	ProofR      *ECPoint         // Schnorr proof of knowledge of Commitments[0]'s discrete log
+	ProofZ      *big.Int
+}
+
+// NewDealerShares runs one dealer's contribution to the Pedersen-style DKG: it samples a
+// random degree-Threshold polynomial, commits to its coefficients, proves knowledge of its
+// own secret coefficient, and evaluates the polynomial at every party index to produce that
+// dealer's share of the eventual group secret. contextMsg should bind the proof to this
+// specific DKG session (e.g. a session ID) to prevent replay across sessions.
+func (params *Parameters) NewDealerShares(dealerIndex int, contextMsg []byte) (*DealerShares, error) {
+	N := params.Curve.Params().N
+	coeffs := make([]*big.Int, params.Threshold+1)
+	for i := range coeffs {
+		c, err := rand.Int(rand.Reader, N)
+		if err != nil {
+			return nil, err
+		}
+		coeffs[i] = c
+	}
+
+	commitments := make([]*ECPoint, len(coeffs))
+	for i, c := range coeffs {
+		x, y := params.Curve.ScalarBaseMult(c.Bytes())
+		commitments[i] = &ECPoint{X: x, Y: y}
+	}
+
+	k, err := rand.Int(rand.Reader, N)
+	if err != nil {
+		return nil, err
+	}
+	Rx, Ry := params.Curve.ScalarBaseMult(k.Bytes())
+	proofR := &ECPoint{X: Rx, Y: Ry}
+	c := dealerProofChallenge(N, dealerIndex, contextMsg, commitments[0], proofR)
+	z := new(big.Int).Mul(c, coeffs[0])
+	z.Add(z, k)
+	z.Mod(z, N)
+
+	shares := make(map[int]*big.Int, params.PartyCount)
+	for j := 1; j <= params.PartyCount; j++ {
+		shares[j] = evalPoly(coeffs, big.NewInt(int64(j)), N)
+	}
+	return &DealerShares{Commitments: commitments, Shares: shares, ProofR: proofR, ProofZ: z}, nil
+}
+
+// VerifyDealerProof checks a dealer's proof of knowledge of its secret coefficient, i.e.
+// the discrete log of Commitments[0]. Callers should reject a dealer's entire contribution
+// if this fails, since it indicates the dealer may not know its own secret and could be
+// attempting a rogue-key attack.
+func VerifyDealerProof(params *Parameters, dealerIndex int, contextMsg []byte, shares *DealerShares) bool {
+	if len(shares.Commitments) == 0 || shares.ProofR == nil || shares.ProofZ == nil {
+		return false
+	}
+	N := params.Curve.Params().N
+	c := dealerProofChallenge(N, dealerIndex, contextMsg, shares.Commitments[0], shares.ProofR)
+
+	lhsX, lhsY := params.Curve.ScalarBaseMult(shares.ProofZ.Bytes())
+	cx, cy := params.Curve.ScalarMult(shares.Commitments[0].X, shares.Commitments[0].Y, c.Bytes())
+	rhsX, rhsY := params.Curve.Add(shares.ProofR.X, shares.ProofR.Y, cx, cy)
+
+	return lhsX.Cmp(rhsX) == 0 && lhsY.Cmp(rhsY) == 0
+}
+
+func dealerProofChallenge(N *big.Int, dealerIndex int, contextMsg []byte, commitment0, R *ECPoint) *big.Int {
+	h := sha256.New()
+	h.Write([]byte("FROST/dkg-pok"))
+	h.Write(big.NewInt(int64(dealerIndex)).Bytes())
+	h.Write(contextMsg)
+	h.Write(commitment0.X.Bytes())
+	h.Write(commitment0.Y.Bytes())
+	h.Write(R.X.Bytes())
+	h.Write(R.Y.Bytes())
+	return new(big.Int).Mod(new(big.Int).SetBytes(h.Sum(nil)), N)
+}
+
+// VerifyShare checks a dealer's Feldman commitments against the share it sent to the party
+// at partyIndex, so a cheating dealer is caught before its share is ever used.
+func VerifyShare(params *Parameters, partyIndex int, share *big.Int, commitments []*ECPoint) bool {
+	if len(commitments) == 0 {
+		return false
+	}
+	lhsX, lhsY := params.Curve.ScalarBaseMult(share.Bytes())
+	rhsX, rhsY := evalCommitments(params, partyIndex, commitments)
+	return lhsX.Cmp(rhsX) == 0 && lhsY.Cmp(rhsY) == 0
+}
+
+// CombineShares sums the shares a party received from every dealer into its final Shamir
+// share xi of the group secret.
+func CombineShares(params *Parameters, dealerShares []*big.Int) *big.Int {
+	sum := new(big.Int)
+	for _, s := range dealerShares {
+		sum.Add(sum, s)
+	}
+	return sum.Mod(sum, params.Curve.Params().N)
+}
+
+// CombineGroupKey sums the constant-term commitments of every dealer to produce the group's
+// public verification key Y.
+func CombineGroupKey(params *Parameters, dealerCommitments [][]*ECPoint) (*ECPoint, error) {
+	if len(dealerCommitments) == 0 {
+		return nil, errors.New("frost: CombineGroupKey: no dealer commitments given")
+	}
+	var x, y *big.Int
+	for _, commitments := range dealerCommitments {
+		if len(commitments) == 0 {
+			return nil, errors.New("frost: CombineGroupKey: a dealer submitted no commitments")
+		}
+		if x == nil {
+			x, y = commitments[0].X, commitments[0].Y
+			continue
+		}
+		x, y = params.Curve.Add(x, y, commitments[0].X, commitments[0].Y)
+	}
+	return &ECPoint{X: x, Y: y}, nil
+}
+
+// CombineVerificationShare sums every dealer's contribution to party partyIndex's public
+// verification share Yi, used during signing to catch a misbehaving signer.
+func CombineVerificationShare(params *Parameters, partyIndex int, dealerCommitments [][]*ECPoint) *ECPoint {
+	var x, y *big.Int
+	for _, commitments := range dealerCommitments {
+		cx, cy := evalCommitments(params, partyIndex, commitments)
+		if x == nil {
+			x, y = cx, cy
+			continue
+		}
+		x, y = params.Curve.Add(x, y, cx, cy)
+	}
+	return &ECPoint{X: x, Y: y}
+}
+
+// evalCommitments evaluates a dealer's Feldman commitments "in the exponent" at partyIndex,
+// i.e. it computes g^f(partyIndex) from the commitments to f's coefficients alone.
+func evalCommitments(params *Parameters, partyIndex int, commitments []*ECPoint) (*big.Int, *big.Int) {
+	N := params.Curve.Params().N
+	x, y := commitments[0].X, commitments[0].Y
+	xPow := big.NewInt(int64(partyIndex))
+	exp := new(big.Int).Set(xPow)
+	for i := 1; i < len(commitments); i++ {
+		cx, cy := params.Curve.ScalarMult(commitments[i].X, commitments[i].Y, exp.Bytes())
+		x, y = params.Curve.Add(x, y, cx, cy)
+		exp.Mul(exp, xPow)
+		exp.Mod(exp, N)
+	}
+	return x, y
+}
+
+func evalPoly(coeffs []*big.Int, x, modulus *big.Int) *big.Int {
+	result := new(big.Int)
+	xPow := big.NewInt(1)
+	for _, c := range coeffs {
+		term := new(big.Int).Mul(c, xPow)
+		result.Add(result, term)
+		result.Mod(result, modulus)
+		xPow.Mul(xPow, x)
+		xPow.Mod(xPow, modulus)
+	}
+	return result
+}
+
+// LagrangeCoefficient computes lambda_i, the Lagrange coefficient for partyIndex over the
+// given set of signer indices, evaluated at x=0 (i.e. for reconstructing f(0)).
+func LagrangeCoefficient(params *Parameters, partyIndex int, signerIndices []int) *big.Int {
+	N := params.Curve.Params().N
+	num := big.NewInt(1)
+	den := big.NewInt(1)
+	xi := big.NewInt(int64(partyIndex))
+	for _, j := range signerIndices {
+		if j == partyIndex {
+			continue
+		}
+		xj := big.NewInt(int64(j))
+		num.Mul(num, xj)
+		num.Mod(num, N)
+		diff := new(big.Int).Sub(xj, xi)
+		diff.Mod(diff, N)
+		den.Mul(den, diff)
+		den.Mod(den, N)
+	}
+	den.ModInverse(den, N)
+	return num.Mul(num, den).Mod(num, N)
+}
+
+// LocalPartySaveData is the per-party output of the FROST DKG: its final signing share,
+// the group's public verification key, and every party's public verification share. It is
+// much smaller than ecdsa/keygen.LocalPartySaveData since no Paillier key or safe primes
+// are required.
+type LocalPartySaveData struct {
+	Index              int
+	Xi                 *big.Int
+	GroupKey           *ECPoint
+	VerificationShares map[int]*ECPoint
+}
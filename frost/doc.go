@@ -0,0 +1,21 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package frost implements FROST (Flexible Round-Optimized Schnorr Threshold signatures):
+// a Pedersen-style DKG that produces Shamir shares of a group Schnorr key with per-share
+// Feldman/Pedersen commitment verification, and a two-round threshold signing protocol.
+// Unlike the ecdsa/keygen package, FROST needs no safe primes or Paillier encryption, so
+// its LocalPartySaveData is small and keygen is comparatively cheap.
+//
+// Parameters.Curve is a crypto/elliptic.Curve, so this package supports short Weierstrass
+// curves such as secp256k1; it does not support twisted Edwards curves like ed25519, which
+// crypto/elliptic cannot represent.
+//
+// This tree has no tss.Party/PartyID/transport abstraction for this package to build on,
+// so the DKG and signing math are exposed as plain functions operating on local data,
+// leaving message passing between parties to the caller. A future transport layer can wrap
+// these functions without changing their signatures.
+package frost
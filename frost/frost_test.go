@@ -0,0 +1,171 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+)
+
+// runDKG has every one of params.PartyCount parties act as a dealer (the standard Pedersen
+// DKG pattern), and returns each party's final LocalPartySaveData.
+func runDKG(t *testing.T, params *Parameters) map[int]*LocalPartySaveData {
+	t.Helper()
+	contextMsg := []byte("frost-test-session")
+
+	dealerShares := make(map[int]*DealerShares, params.PartyCount)
+	for d := 1; d <= params.PartyCount; d++ {
+		ds, err := params.NewDealerShares(d, contextMsg)
+		if err != nil {
+			t.Fatalf("dealer %d: NewDealerShares: %v", d, err)
+		}
+		if !VerifyDealerProof(params, d, contextMsg, ds) {
+			t.Fatalf("dealer %d: VerifyDealerProof failed for an honest dealer", d)
+		}
+		dealerShares[d] = ds
+	}
+
+	allCommitments := make([][]*ECPoint, 0, params.PartyCount)
+	for d := 1; d <= params.PartyCount; d++ {
+		allCommitments = append(allCommitments, dealerShares[d].Commitments)
+	}
+	groupKey, err := CombineGroupKey(params, allCommitments)
+	if err != nil {
+		t.Fatalf("CombineGroupKey: %v", err)
+	}
+
+	saveData := make(map[int]*LocalPartySaveData, params.PartyCount)
+	for p := 1; p <= params.PartyCount; p++ {
+		sharesFromEachDealer := make([]*big.Int, 0, params.PartyCount)
+		for d := 1; d <= params.PartyCount; d++ {
+			share := dealerShares[d].Shares[p]
+			if !VerifyShare(params, p, share, dealerShares[d].Commitments) {
+				t.Fatalf("party %d: VerifyShare failed for dealer %d's honest share", p, d)
+			}
+			sharesFromEachDealer = append(sharesFromEachDealer, share)
+		}
+		xi := CombineShares(params, sharesFromEachDealer)
+		verificationShare := CombineVerificationShare(params, p, allCommitments)
+		saveData[p] = &LocalPartySaveData{
+			Index:              p,
+			Xi:                 xi,
+			GroupKey:           groupKey,
+			VerificationShares: map[int]*ECPoint{p: verificationShare},
+		}
+	}
+	return saveData
+}
+
+// sign drives a full two-round signing session for the given subset of signer indices and
+// returns the aggregated signature.
+func sign(t *testing.T, params *Parameters, saveData map[int]*LocalPartySaveData, signerIndices []int, msg []byte) *Signature {
+	t.Helper()
+
+	secrets := make(map[int]*NonceSecret, len(signerIndices))
+	commitments := make([]*NonceCommitment, 0, len(signerIndices))
+	for _, i := range signerIndices {
+		secret, commitment, err := GenerateNonces(params, i)
+		if err != nil {
+			t.Fatalf("party %d: GenerateNonces: %v", i, err)
+		}
+		secrets[i] = secret
+		commitments = append(commitments, commitment)
+	}
+
+	R, rho, err := GroupCommitment(params, msg, commitments)
+	if err != nil {
+		t.Fatalf("GroupCommitment: %v", err)
+	}
+
+	zis := make(map[int]*big.Int, len(signerIndices))
+	for idx, i := range signerIndices {
+		zi := Sign(params, i, secrets[i], saveData[i].Xi, saveData[i].GroupKey, msg, R, rho, signerIndices)
+		if !VerifyPartialSignature(params, i, zi, commitments[idx], saveData[i].VerificationShares[i], saveData[i].GroupKey, msg, R, rho, signerIndices) {
+			t.Fatalf("party %d: VerifyPartialSignature failed for an honest partial signature", i)
+		}
+		zis[i] = zi
+	}
+
+	return Aggregate(params, R, zis)
+}
+
+func TestFROST_ThresholdSubsetsProduceValidSignatures(t *testing.T) {
+	params, err := NewParameters(elliptic.P256(), 1, 3)
+	if err != nil {
+		t.Fatalf("NewParameters: %v", err)
+	}
+	saveData := runDKG(t, params)
+	msg := []byte("hello frost")
+
+	for _, signerIndices := range [][]int{{1, 2}, {1, 3}, {2, 3}} {
+		sig := sign(t, params, saveData, signerIndices, msg)
+		if !Verify(params, saveData[signerIndices[0]].GroupKey, msg, sig) {
+			t.Fatalf("signature from signer subset %v did not verify", signerIndices)
+		}
+	}
+}
+
+func TestFROST_RogueKeyAttackRejected(t *testing.T) {
+	params, err := NewParameters(elliptic.P256(), 1, 3)
+	if err != nil {
+		t.Fatalf("NewParameters: %v", err)
+	}
+	contextMsg := []byte("frost-test-session")
+
+	ds, err := params.NewDealerShares(1, contextMsg)
+	if err != nil {
+		t.Fatalf("NewDealerShares: %v", err)
+	}
+	if !VerifyDealerProof(params, 1, contextMsg, ds) {
+		t.Fatal("expected an honest dealer's proof to verify")
+	}
+
+	// A dealer attempting a rogue-key attack cannot produce a valid proof of knowledge
+	// without knowing the discrete log of its commitment; simulate that by tampering with
+	// the response and checking the forged proof is rejected.
+	tampered := *ds
+	tampered.ProofZ = new(big.Int).Add(ds.ProofZ, big.NewInt(1))
+	if VerifyDealerProof(params, 1, contextMsg, &tampered) {
+		t.Fatal("expected a tampered proof of knowledge to be rejected")
+	}
+}
+
+func TestFROST_EmptyCommitmentsRejected(t *testing.T) {
+	params, err := NewParameters(elliptic.P256(), 1, 3)
+	if err != nil {
+		t.Fatalf("NewParameters: %v", err)
+	}
+	if VerifyShare(params, 1, big.NewInt(1), nil) {
+		t.Fatal("expected VerifyShare to reject empty commitments rather than panic")
+	}
+}
+
+func TestFROST_TamperedMessageAndNonceRejected(t *testing.T) {
+	params, err := NewParameters(elliptic.P256(), 1, 3)
+	if err != nil {
+		t.Fatalf("NewParameters: %v", err)
+	}
+	saveData := runDKG(t, params)
+	msg := []byte("hello frost")
+	signerIndices := []int{1, 2}
+
+	sig := sign(t, params, saveData, signerIndices, msg)
+	if !Verify(params, saveData[1].GroupKey, msg, sig) {
+		t.Fatal("expected the honestly produced signature to verify")
+	}
+
+	tamperedMsg := []byte("goodbye frost")
+	if Verify(params, saveData[1].GroupKey, tamperedMsg, sig) {
+		t.Fatal("expected verification to fail against a tampered message")
+	}
+
+	tamperedSig := &Signature{R: sig.R, Z: new(big.Int).Add(sig.Z, big.NewInt(1))}
+	if Verify(params, saveData[1].GroupKey, msg, tamperedSig) {
+		t.Fatal("expected verification to fail against a tampered response")
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/elliptic"
+	"errors"
+)
+
+// Parameters describes a (Threshold+1)-of-PartyCount FROST setup: any Threshold+1 of the
+// PartyCount signers can produce a valid signature under the group key. Party indices used
+// throughout this package are the integers 1..PartyCount (never 0, since a Shamir share at
+// x=0 would leak the secret).
+type Parameters struct {
+	Curve      elliptic.Curve
+	Threshold  int
+	PartyCount int
+}
+
+// NewParameters validates and constructs FROST parameters for a (Threshold+1)-of-PartyCount
+// signing group over Curve.
+func NewParameters(curve elliptic.Curve, threshold, partyCount int) (*Parameters, error) {
+	if threshold < 1 {
+		return nil, errors.New("frost: NewParameters: threshold must be at least 1")
+	}
+	if partyCount < threshold+1 {
+		return nil, errors.New("frost: NewParameters: partyCount must be greater than threshold")
+	}
+	return &Parameters{Curve: curve, Threshold: threshold, PartyCount: partyCount}, nil
+}
@@ -0,0 +1,21 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import "math/big"
+
+// ECPoint is a point on the curve in affine coordinates.
+type ECPoint struct {
+	X, Y *big.Int
+}
+
+func (p *ECPoint) Equals(other *ECPoint) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return p.X.Cmp(other.X) == 0 && p.Y.Cmp(other.Y) == 0
+}
@@ -0,0 +1,163 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// NonceSecret holds a signer's two private round-1 nonces (d, e). It must never leave the
+// signer and must never be reused across signing sessions.
+type NonceSecret struct {
+	d, e *big.Int
+}
+
+// NonceCommitment is the public round-1 message a signer broadcasts: (D, E) = (d*G, e*G).
+type NonceCommitment struct {
+	Index int
+	D, E  *ECPoint
+}
+
+// GenerateNonces performs a signer's round 1: sample two nonces and publish their commitments.
+func GenerateNonces(params *Parameters, index int) (*NonceSecret, *NonceCommitment, error) {
+	N := params.Curve.Params().N
+	d, err := rand.Int(rand.Reader, N)
+	if err != nil {
+		return nil, nil, err
+	}
+	e, err := rand.Int(rand.Reader, N)
+	if err != nil {
+		return nil, nil, err
+	}
+	dx, dy := params.Curve.ScalarBaseMult(d.Bytes())
+	ex, ey := params.Curve.ScalarBaseMult(e.Bytes())
+	secret := &NonceSecret{d: d, e: e}
+	commitment := &NonceCommitment{Index: index, D: &ECPoint{X: dx, Y: dy}, E: &ECPoint{X: ex, Y: ey}}
+	return secret, commitment, nil
+}
+
+// bindingValue computes rho_i = H1(i, msg, B), binding signer i's nonces to this signing
+// session so a signer cannot be tricked into reusing commitments across messages.
+func bindingValue(N *big.Int, index int, msg []byte, commitments []*NonceCommitment) *big.Int {
+	h := sha256.New()
+	h.Write([]byte("FROST/rho"))
+	h.Write(big.NewInt(int64(index)).Bytes())
+	h.Write(msg)
+	for _, c := range commitments {
+		h.Write(big.NewInt(int64(c.Index)).Bytes())
+		h.Write(c.D.X.Bytes())
+		h.Write(c.D.Y.Bytes())
+		h.Write(c.E.X.Bytes())
+		h.Write(c.E.Y.Bytes())
+	}
+	return new(big.Int).Mod(new(big.Int).SetBytes(h.Sum(nil)), N)
+}
+
+// challenge computes c = H2(R, Y, msg), the Schnorr challenge binding the group commitment
+// and group public key to the message being signed.
+func challenge(N *big.Int, R, Y *ECPoint, msg []byte) *big.Int {
+	h := sha256.New()
+	h.Write([]byte("FROST/c"))
+	h.Write(R.X.Bytes())
+	h.Write(R.Y.Bytes())
+	h.Write(Y.X.Bytes())
+	h.Write(Y.Y.Bytes())
+	h.Write(msg)
+	return new(big.Int).Mod(new(big.Int).SetBytes(h.Sum(nil)), N)
+}
+
+// GroupCommitment computes the round-2 group nonce commitment R = sum(D_i + rho_i * E_i)
+// over the set of signers participating in this session, and the per-signer binding values
+// rho_i used to compute both R and each signer's response z_i.
+func GroupCommitment(params *Parameters, msg []byte, commitments []*NonceCommitment) (R *ECPoint, rho map[int]*big.Int, err error) {
+	if len(commitments) < params.Threshold+1 {
+		return nil, nil, errors.New("frost: GroupCommitment: fewer than Threshold+1 signers")
+	}
+	N := params.Curve.Params().N
+	rho = make(map[int]*big.Int, len(commitments))
+	var x, y *big.Int
+	for _, c := range commitments {
+		rho[c.Index] = bindingValue(N, c.Index, msg, commitments)
+		ex, ey := params.Curve.ScalarMult(c.E.X, c.E.Y, rho[c.Index].Bytes())
+		px, py := params.Curve.Add(c.D.X, c.D.Y, ex, ey)
+		if x == nil {
+			x, y = px, py
+			continue
+		}
+		x, y = params.Curve.Add(x, y, px, py)
+	}
+	return &ECPoint{X: x, Y: y}, rho, nil
+}
+
+// Sign performs a signer's round 2: given its nonce secret, its Shamir share xi, the group
+// commitment R, the per-signer binding values rho and the indices of the participating
+// signers, it returns zi = d + e*rho_i + lambda_i*xi*c.
+func Sign(params *Parameters, index int, nonce *NonceSecret, xi *big.Int, groupKey *ECPoint, msg []byte, R *ECPoint, rho map[int]*big.Int, signerIndices []int) *big.Int {
+	N := params.Curve.Params().N
+	c := challenge(N, R, groupKey, msg)
+	lambda := LagrangeCoefficient(params, index, signerIndices)
+
+	z := new(big.Int).Mul(nonce.e, rho[index])
+	z.Add(z, nonce.d)
+	term := new(big.Int).Mul(lambda, xi)
+	term.Mul(term, c)
+	z.Add(z, term)
+	return z.Mod(z, N)
+}
+
+// VerifyPartialSignature checks signer index's contribution zi against its public
+// commitments and verification share, so a misbehaving signer can be identified before the
+// aggregate signature is assembled: g^zi =? D_i + rho_i*E_i + (lambda_i*c)*Yi.
+func VerifyPartialSignature(params *Parameters, index int, zi *big.Int, commitment *NonceCommitment, verificationShare *ECPoint, groupKey *ECPoint, msg []byte, R *ECPoint, rho map[int]*big.Int, signerIndices []int) bool {
+	N := params.Curve.Params().N
+	c := challenge(N, R, groupKey, msg)
+	lambda := LagrangeCoefficient(params, index, signerIndices)
+
+	lhsX, lhsY := params.Curve.ScalarBaseMult(zi.Bytes())
+
+	ex, ey := params.Curve.ScalarMult(commitment.E.X, commitment.E.Y, rho[index].Bytes())
+	rhsX, rhsY := params.Curve.Add(commitment.D.X, commitment.D.Y, ex, ey)
+
+	lambdaC := new(big.Int).Mul(lambda, c)
+	lambdaC.Mod(lambdaC, N)
+	yx, yy := params.Curve.ScalarMult(verificationShare.X, verificationShare.Y, lambdaC.Bytes())
+	rhsX, rhsY = params.Curve.Add(rhsX, rhsY, yx, yy)
+
+	return lhsX.Cmp(rhsX) == 0 && lhsY.Cmp(rhsY) == 0
+}
+
+// Signature is a FROST Schnorr signature: the group nonce commitment R and response z.
+type Signature struct {
+	R *ECPoint
+	Z *big.Int
+}
+
+// Aggregate sums the per-signer responses zi into the final signature response z.
+func Aggregate(params *Parameters, R *ECPoint, zis map[int]*big.Int) *Signature {
+	N := params.Curve.Params().N
+	z := new(big.Int)
+	for _, zi := range zis {
+		z.Add(z, zi)
+	}
+	return &Signature{R: R, Z: z.Mod(z, N)}
+}
+
+// Verify checks a complete FROST signature against the group public key: z*G =? R + c*Y.
+func Verify(params *Parameters, groupKey *ECPoint, msg []byte, sig *Signature) bool {
+	N := params.Curve.Params().N
+	c := challenge(N, sig.R, groupKey, msg)
+
+	lhsX, lhsY := params.Curve.ScalarBaseMult(sig.Z.Bytes())
+
+	cx, cy := params.Curve.ScalarMult(groupKey.X, groupKey.Y, c.Bytes())
+	rhsX, rhsY := params.Curve.Add(sig.R.X, sig.R.Y, cx, cy)
+
+	return lhsX.Cmp(rhsX) == 0 && lhsY.Cmp(rhsY) == 0
+}